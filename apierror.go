@@ -0,0 +1,24 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// APIError is the JSON envelope returned for every non-2xx response.
+type APIError struct {
+	Code    int    `json:"code" example:"404"`
+	Message string `json:"message" example:"movie not found"`
+	Details string `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// writeError writes an APIError envelope to w with the given HTTP status.
+func writeError(w http.ResponseWriter, status int, message, details string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(APIError{Code: status, Message: message, Details: details})
+}
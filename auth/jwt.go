@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims is the JWT payload issued by Authenticator, carrying the user's
+// role alongside the standard registered claims.
+type Claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+var (
+	// ErrInvalidCredentials is returned by Login on a bad username/password.
+	ErrInvalidCredentials = errors.New("auth: invalid username or password")
+	// ErrInvalidToken is returned by Refresh (and surfaced by Require) when
+	// the presented token doesn't parse, is unsigned by us, or has expired.
+	ErrInvalidToken = errors.New("auth: invalid or expired token")
+)
+
+func (a *Authenticator) newToken(username, role string) (token string, expiresAt time.Time, err error) {
+	now := time.Now()
+	expiresAt = now.Add(a.ttl)
+	claims := Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   username,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.secret)
+	return token, expiresAt, err
+}
+
+func (a *Authenticator) parseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return a.secret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// roleRank orders roles so Require can check "at least as privileged as".
+var roleRank = map[string]int{"reader": 1, "editor": 2, "admin": 3}
+
+// Require wraps next so it only runs for requests bearing a valid token
+// whose role meets or exceeds role (reader < editor < admin). Mount it per
+// route, e.g. r.Handle("/movies", auth.Require("editor", createMovie)).
+func (a *Authenticator) Require(role string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenString := bearerToken(r)
+		if tokenString == "" {
+			a.writeError(w, http.StatusUnauthorized, "missing bearer token", "")
+			return
+		}
+
+		claims, err := a.parseToken(tokenString)
+		if err != nil {
+			a.writeError(w, http.StatusUnauthorized, "invalid or expired token", err.Error())
+			return
+		}
+
+		if roleRank[claims.Role] < roleRank[role] {
+			a.writeError(w, http.StatusForbidden, "insufficient role", fmt.Sprintf("%s role required", role))
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(h, prefix)
+}
+
+func (a *Authenticator) writeError(w http.ResponseWriter, status int, message, details string) {
+	if a.WriteError != nil {
+		a.WriteError(w, status, message, details)
+		return
+	}
+	http.Error(w, message, status)
+}
+
+// LoginRequest is the body of POST /auth/login.
+type LoginRequest struct {
+	Username string `json:"username" validate:"required" example:"editor"`
+	Password string `json:"password" validate:"required" example:"editor123"`
+}
+
+// TokenResponse is the body returned by /auth/login and /auth/refresh.
+type TokenResponse struct {
+	Token     string `json:"token"`
+	Role      string `json:"role" example:"editor"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// LoginHandler handles POST /auth/login.
+func (a *Authenticator) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		a.writeError(w, http.StatusBadRequest, "malformed JSON body", err.Error())
+		return
+	}
+
+	token, role, expiresAt, err := a.Login(req.Username, req.Password)
+	if err != nil {
+		a.writeError(w, http.StatusUnauthorized, "invalid username or password", "")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, TokenResponse{Token: token, Role: role, ExpiresAt: expiresAt.Format(time.RFC3339)})
+}
+
+// RefreshHandler handles POST /auth/refresh, exchanging a still-valid
+// bearer token for a new one with a refreshed expiry.
+func (a *Authenticator) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	tokenString := bearerToken(r)
+	if tokenString == "" {
+		a.writeError(w, http.StatusUnauthorized, "missing bearer token", "")
+		return
+	}
+
+	token, role, expiresAt, err := a.Refresh(tokenString)
+	if err != nil {
+		a.writeError(w, http.StatusUnauthorized, "invalid or expired token", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, TokenResponse{Token: token, Role: role, ExpiresAt: expiresAt.Format(time.RFC3339)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
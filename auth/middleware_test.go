@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	users := NewUserStore()
+	if err := users.AddUser("reader1", "password", "reader"); err != nil {
+		t.Fatalf("AddUser: %v", err)
+	}
+	if err := users.AddUser("editor1", "password", "editor"); err != nil {
+		t.Fatalf("AddUser: %v", err)
+	}
+	if err := users.AddUser("admin1", "password", "admin"); err != nil {
+		t.Fatalf("AddUser: %v", err)
+	}
+	return New([]byte("test-secret"), users, time.Hour)
+}
+
+func tokenFor(t *testing.T, a *Authenticator, username, password string) string {
+	t.Helper()
+	token, _, _, err := a.Login(username, password)
+	if err != nil {
+		t.Fatalf("Login(%q): %v", username, err)
+	}
+	return token
+}
+
+func TestRequireRoleHierarchy(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	tests := []struct {
+		name       string
+		username   string
+		password   string
+		needsRole  string
+		wantStatus int
+	}{
+		{"reader may access reader route", "reader1", "password", "reader", http.StatusOK},
+		{"editor may access reader route", "editor1", "password", "reader", http.StatusOK},
+		{"admin may access reader route", "admin1", "password", "reader", http.StatusOK},
+		{"reader may not access editor route", "reader1", "password", "editor", http.StatusForbidden},
+		{"editor may access editor route", "editor1", "password", "editor", http.StatusOK},
+		{"editor may not access admin route", "editor1", "password", "admin", http.StatusForbidden},
+		{"admin may access admin route", "admin1", "password", "admin", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tokenFor(t, a, tt.username, tt.password)
+
+			handler := a.Require(tt.needsRole, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/movies", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			handler(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRequireMissingToken(t *testing.T) {
+	a := newTestAuthenticator(t)
+	handler := a.Require("reader", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/movies", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireInvalidToken(t *testing.T) {
+	a := newTestAuthenticator(t)
+	handler := a.Require("reader", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/movies", nil)
+	req.Header.Set("Authorization", "Bearer not-a-real-token")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
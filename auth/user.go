@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// User is an account that can authenticate against /auth/login.
+type User struct {
+	Username     string
+	PasswordHash []byte
+	Role         string
+}
+
+// UserStore is an in-memory, bcrypt-hashed user directory.
+type UserStore struct {
+	mu    sync.RWMutex
+	users map[string]User
+}
+
+// NewUserStore returns an empty UserStore.
+func NewUserStore() *UserStore {
+	return &UserStore{users: make(map[string]User)}
+}
+
+// AddUser hashes password and registers (or replaces) username with the
+// given role ("reader", "editor", or "admin").
+func (s *UserStore) AddUser(username, password, role string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[username] = User{Username: username, PasswordHash: hash, Role: role}
+	return nil
+}
+
+// Authenticate reports whether username/password match a registered user,
+// returning that user on success.
+func (s *UserStore) Authenticate(username, password string) (User, bool) {
+	s.mu.RLock()
+	user, ok := s.users[username]
+	s.mu.RUnlock()
+	if !ok {
+		return User{}, false
+	}
+	if bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password)) != nil {
+		return User{}, false
+	}
+	return user, true
+}
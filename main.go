@@ -1,186 +1,359 @@
-package main
-
-import (
-	"encoding/json"
-	"fmt"
-	"log"
-	"math/rand"
-	"net/http"
-	"strconv"
-
-	"github.com/gorilla/mux"
-)
-
-// this creates a blueprint for the movie and director information. we include
-// a JSON tag to specify how the field will be represented when it is marshaled
-// to JSON. each movie also aggregates a director - it is associated with
-// an existing director instance, exemplified by using a pointer to a director.
-type Movie struct {
-	ID       string    `json:"id"`
-	Isbn     string    `json:"isbn"`
-	Title    string    `json:"title"`
-	Director *Director `json:"director"`
-}
-type Director struct {
-	Firstname string `json:"firstname"`
-	Lastname  string `json:"lastname"`
-}
-
-// creating a slice to store our movies in
-var movies []Movie
-
-// this function is a handler for GET requests to the /movies endpoint of the
-// server. it takes in w, a ResponseWriter, which allows us to directly interact
-// with the HTTP response, and it also takes in r, a pointer to a request.
-func getMovies(w http.ResponseWriter, r *http.Request) {
-
-	// this sets the "Content-Type" header of the HTTP response to JSON format.
-	w.Header().Set("Content-Type", "application/json")
-
-	// json.NewEncoder is an object that writes data that is in JSON format to w.
-	// .Encode(movies) actually marshals the movies slice into JSON format and
-	// then writes it to w.
-	json.NewEncoder(w).Encode(movies)
-}
-
-// this function is a handler for DELETE requests to the /movies/{id} endpoint
-// of the server.
-func deleteMovie(w http.ResponseWriter, r *http.Request) {
-
-	// this sets the "Content-Type" header of the HTTP response to JSON format.
-	// NOTE: although this line is not particularly useful since we do not
-	// actually write anything to the HTTP response in this function, leaving
-	// it here is good practice and maintains code consistency.
-	w.Header().Set("Content-Type", "application/json")
-
-	// mux.Vars(r) takes in a Request and returns any URL variables in the
-	// route pattern as a map. for this specific request, we would extract
-	// whatever the client put in for {id} in the "/movies/{id}" route pattern.
-	params := mux.Vars(r)
-
-	// iterate through the slice of movies. if the movie at `i`'s ID has the
-	// same contents as the id key in params, we will remove that movie from
-	// `movies` and break from the for loop.
-	for i := 0; i < len(movies); i++ {
-		if movies[i].ID == params["id"] {
-			movies = append(movies[:i], movies[i+1:]...)
-			break
-		}
-	}
-}
-
-// this function is a handler for GET requests to the /movies/{id} endpoint
-// of the server.
-func getMovie(w http.ResponseWriter, r *http.Request) {
-
-	// this sets the "Content-Type" header of the HTTP response to JSON format.
-	w.Header().Set("Content-Type", "application/json")
-
-	// mux.Vars(r) takes in a Request and returns any URL variables in the
-	// route pattern as a map. for this specific request, we would extract
-	// whatever the client put in for {id} in the "/movies/{id}" route pattern.
-	params := mux.Vars(r)
-
-	// iterate through the slice of movies. if the movie at `i`'s ID has the
-	// same contents as the id key in params, we will write that movie in JSON
-	// format to the HTTP response and return.
-	for i := 0; i < len(movies); i++ {
-		if movies[i].ID == params["id"] {
-			json.NewEncoder(w).Encode(movies[i])
-			return
-		}
-	}
-}
-
-// this function is a handler for POST requests to the /movies endpoint of the
-// server. it will create a new movie and then send it back as a JSON response.
-func createMovie(w http.ResponseWriter, r *http.Request) {
-
-	// this sets the "Content-Type" header of the HTTP response to JSON format.
-	w.Header().Set("Content-Type", "application/json")
-
-	// declare a movie variable. then we unmarshal the movie data from the
-	// request's body and store it in the value pointed to by `movie`
-	var movie Movie
-	json.NewDecoder(r.Body).Decode(&movie)
-
-	// generate a random integer from 0 - 999999, convert it to a string, and
-	// set the movie's ID to it.
-	movie.ID = strconv.Itoa(rand.Intn(1000000))
-
-	// update `movies` to include the newly created movie
-	movies = append(movies, movie)
-
-	// marshal the movie back into JSON and write it to the HTTP response
-	json.NewEncoder(w).Encode(movie)
-}
-
-// this function is a handler for PUT requests to the /movies/{id} endpoint of
-// the server. it allows us to update the contents of a movie.
-func updateMovie(w http.ResponseWriter, r *http.Request) {
-
-	// this sets the "Content-Type" header of the HTTP response to JSON format.
-	w.Header().Set("Content-Type", "application/json")
-
-	// store the movie id specified in the route pattern as a key value pair
-	params := mux.Vars(r)
-
-	// iterate through `movies`. if a movie ID matches the ID extracted from
-	// the route pattern, remove the existing version of that movie from
-	// `movies`. then create a new movie with the updated information (see
-	// createMovie) and append it to `movies`. write the updated movie in JSON
-	// format to w and return.
-	for i := 0; i < len(movies); i++ {
-		if movies[i].ID == params["id"] {
-			movies = append(movies[:i], movies[i+1:]...)
-			var movie Movie
-			json.NewDecoder(r.Body).Decode(&movie)
-			movie.ID = params["id"]
-			movies = append(movies, movie)
-			json.NewEncoder(w).Encode(movie)
-			return
-		}
-	}
-}
-
-func main() {
-	// creating a mux.Router instance. this Router will allow us to create
-	// Routes that match HTTP requests to the correct handler functions based on
-	// the URL path that the request is made to.
-	r := mux.NewRouter()
-
-	// adding some movies to our slice so that when we send a GET request to
-	// /movies, there will actually be a result
-	movies = append(movies, Movie{
-		ID:    "1",
-		Isbn:  "123456",
-		Title: "Movie One",
-		Director: &Director{
-			Firstname: "Lebron",
-			Lastname:  "James",
-		},
-	})
-	movies = append(movies, Movie{
-		ID:    "2",
-		Isbn:  "654321",
-		Title: "Movie Two",
-		Director: &Director{
-			Firstname: "Joe",
-			Lastname:  "Biden",
-		},
-	})
-
-	// actually defining the routes as stated above. for example, the line below
-	// creates a route that handles GET requests to /movies by calling getMovies
-	r.HandleFunc("/movies", getMovies).Methods("GET")
-	r.HandleFunc("/movies/{id}", getMovie).Methods("GET")
-	r.HandleFunc("/movies", createMovie).Methods("POST")
-	r.HandleFunc("/movies/{id}", updateMovie).Methods("PUT")
-	r.HandleFunc("/movies/{id}", deleteMovie).Methods("DELETE")
-
-	// starting the server and telling it to listen on port 8000 while using r
-	// (the router we defined earlier) to handle any requests. if a non-nil
-	// error is returned by ListenAndServe, we will log it and exit the program.
-	fmt.Println("Starting server at port 8000")
-	log.Fatal(http.ListenAndServe(":8000", r))
-}
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/shui08/go-crud-api/auth"
+	"github.com/shui08/go-crud-api/openapi"
+	"github.com/shui08/go-crud-api/storage"
+)
+
+// MovieHandler wires the /movies routes to a storage.Storage. It is a
+// struct rather than package-level functions over a global slice so tests
+// can construct one against a fake Storage instead of a real database.
+type MovieHandler struct {
+	Store storage.Storage
+}
+
+// this function is a handler for GET requests to the /movies endpoint of the
+// server. it takes in w, a ResponseWriter, which allows us to directly interact
+// with the HTTP response, and it also takes in r, a pointer to a request.
+func (h *MovieHandler) getMovies(w http.ResponseWriter, r *http.Request) {
+
+	opts, err := parseListOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid query parameters", err.Error())
+		return
+	}
+
+	movies, total, err := h.Store.List(opts)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list movies", err.Error())
+		return
+	}
+	if movies == nil {
+		movies = []storage.Movie{}
+	}
+
+	setPaginationLinks(w, r, opts, total)
+
+	// this sets the "Content-Type" header of the HTTP response to JSON format.
+	w.Header().Set("Content-Type", "application/json")
+
+	limit := 0
+	if opts.Limit != nil {
+		limit = *opts.Limit
+	}
+
+	// json.NewEncoder is an object that writes data that is in JSON format to w.
+	// .Encode marshals the pagination envelope into JSON format and writes
+	// it to w.
+	json.NewEncoder(w).Encode(MoviesPage{
+		Data:   movies,
+		Total:  total,
+		Limit:  limit,
+		Offset: opts.Offset,
+	})
+}
+
+// this function is a handler for DELETE requests to the /movies/{id} endpoint
+// of the server.
+func (h *MovieHandler) deleteMovie(w http.ResponseWriter, r *http.Request) {
+
+	// mux.Vars(r) takes in a Request and returns any URL variables in the
+	// route pattern as a map. for this specific request, we would extract
+	// whatever the client put in for {id} in the "/movies/{id}" route pattern.
+	params := mux.Vars(r)
+
+	if err := h.Store.Delete(params["id"]); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "movie not found", "")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to delete movie", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// this function is a handler for GET requests to the /movies/{id} endpoint
+// of the server.
+func (h *MovieHandler) getMovie(w http.ResponseWriter, r *http.Request) {
+
+	// mux.Vars(r) takes in a Request and returns any URL variables in the
+	// route pattern as a map. for this specific request, we would extract
+	// whatever the client put in for {id} in the "/movies/{id}" route pattern.
+	params := mux.Vars(r)
+
+	movie, err := h.Store.Get(params["id"])
+	if errors.Is(err, storage.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "movie not found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get movie", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(movie)
+}
+
+// this function is a handler for POST requests to the /movies endpoint of the
+// server. it will create a new movie and then send it back as a JSON response.
+func (h *MovieHandler) createMovie(w http.ResponseWriter, r *http.Request) {
+
+	// declare a movie variable. then we unmarshal the movie data from the
+	// request's body and store it in the value pointed to by `movie`
+	var movie storage.Movie
+	if err := json.NewDecoder(r.Body).Decode(&movie); err != nil {
+		writeError(w, http.StatusBadRequest, "malformed JSON body", err.Error())
+		return
+	}
+
+	if problems := validateMovie(movie); len(problems) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "validation failed", strings.Join(problems, "; "))
+		return
+	}
+
+	// IDs are UUIDs drawn from crypto/rand (via google/uuid) rather than
+	// math/rand's 0-999999 range, which could collide.
+	id, err := uuid.NewRandom()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to generate id", err.Error())
+		return
+	}
+	movie.ID = id.String()
+
+	created, err := h.Store.Create(movie)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create movie", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/movies/"+created.ID)
+	w.WriteHeader(http.StatusCreated)
+	// marshal the movie back into JSON and write it to the HTTP response
+	json.NewEncoder(w).Encode(created)
+}
+
+// this function is a handler for PUT requests to the /movies/{id} endpoint of
+// the server. it allows us to update the contents of a movie.
+func (h *MovieHandler) updateMovie(w http.ResponseWriter, r *http.Request) {
+
+	// store the movie id specified in the route pattern as a key value pair
+	params := mux.Vars(r)
+
+	var movie storage.Movie
+	if err := json.NewDecoder(r.Body).Decode(&movie); err != nil {
+		writeError(w, http.StatusBadRequest, "malformed JSON body", err.Error())
+		return
+	}
+
+	if problems := validateMovie(movie); len(problems) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "validation failed", strings.Join(problems, "; "))
+		return
+	}
+
+	updated, err := h.Store.Update(params["id"], movie)
+	if errors.Is(err, storage.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "movie not found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update movie", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// patchMovie is a handler for PATCH requests to the /movies/{id} endpoint.
+// Unlike updateMovie (PUT, full replace), it applies an RFC 7396 JSON
+// Merge Patch body on top of the existing movie, so a patch touching only
+// movie.title leaves the nested director untouched.
+func (h *MovieHandler) patchMovie(w http.ResponseWriter, r *http.Request) {
+
+	if ct := r.Header.Get("Content-Type"); ct != "application/merge-patch+json" {
+		writeError(w, http.StatusUnsupportedMediaType, "unsupported content type", "expected application/merge-patch+json")
+		return
+	}
+
+	params := mux.Vars(r)
+
+	existing, err := h.Store.Get(params["id"])
+	if errors.Is(err, storage.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "movie not found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get movie", err.Error())
+		return
+	}
+
+	var patch map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "malformed JSON body", err.Error())
+		return
+	}
+
+	existingDoc, err := toDoc(existing)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to apply patch", err.Error())
+		return
+	}
+
+	var patched storage.Movie
+	if err := fromDoc(mergePatch(existingDoc, patch), &patched); err != nil {
+		writeError(w, http.StatusBadRequest, "patch result is not a valid movie", err.Error())
+		return
+	}
+
+	if problems := validateMovie(patched); len(problems) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "validation failed", strings.Join(problems, "; "))
+		return
+	}
+
+	updated, err := h.Store.Update(params["id"], patched)
+	if errors.Is(err, storage.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "movie not found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update movie", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// newStorage builds the Storage backend selected by the --storage flag.
+func newStorage(kind, dsn, filePath string) (storage.Storage, error) {
+	switch kind {
+	case "memory":
+		mem := storage.NewMemory()
+		// seed some movies so that when we send a GET request to /movies,
+		// there will actually be a result
+		mem.Seed(
+			storage.Movie{
+				ID:    "1",
+				Isbn:  "9780306406157",
+				Title: "Movie One",
+				Director: &storage.Director{
+					Firstname: "Lebron",
+					Lastname:  "James",
+				},
+			},
+			storage.Movie{
+				ID:    "2",
+				Isbn:  "9780132350884",
+				Title: "Movie Two",
+				Director: &storage.Director{
+					Firstname: "Joe",
+					Lastname:  "Biden",
+				},
+			},
+		)
+		return mem, nil
+	case "file":
+		return storage.NewFile(filePath)
+	case "sqlite":
+		return storage.OpenSQL("sqlite3", dsn)
+	case "postgres":
+		return storage.OpenSQL("postgres", dsn)
+	case "mongo":
+		uri := os.Getenv("MONGO_URI")
+		if uri == "" {
+			return nil, fmt.Errorf("--storage=mongo requires the MONGO_URI environment variable")
+		}
+		return storage.OpenMongo(context.Background(), uri, "go-crud-api", "movies")
+	default:
+		return nil, fmt.Errorf("unknown --storage backend %q (want memory, file, sqlite, postgres, or mongo)", kind)
+	}
+}
+
+// seedUsers populates the in-memory user directory with one demo account
+// per role, since there is no registration endpoint yet.
+func seedUsers(store *auth.UserStore) error {
+	demo := []struct{ username, password, role string }{
+		{"reader", "reader123", "reader"},
+		{"editor", "editor123", "editor"},
+		{"admin", "admin123", "admin"},
+	}
+	for _, u := range demo {
+		if err := store.AddUser(u.username, u.password, u.role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	storageKind := flag.String("storage", "memory", "storage backend: memory, file, sqlite, postgres, or mongo")
+	dsn := flag.String("dsn", "movies.db", "data source name for the sqlite/postgres backends")
+	filePath := flag.String("file", "movies.json", "path to the JSON file used by the file backend")
+	jwtSecret := flag.String("jwt-secret", "dev-secret-change-me", "HMAC secret used to sign JWTs (HS256)")
+	flag.Parse()
+
+	store, err := newStorage(*storageKind, *dsn, *filePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	handler := &MovieHandler{Store: store}
+
+	users := auth.NewUserStore()
+	if err := seedUsers(users); err != nil {
+		log.Fatal(err)
+	}
+
+	authenticator := auth.New([]byte(*jwtSecret), users, 15*time.Minute)
+	authenticator.WriteError = writeError
+
+	// creating a mux.Router instance. this Router will allow us to create
+	// Routes that match HTTP requests to the correct handler functions based on
+	// the URL path that the request is made to.
+	r := mux.NewRouter()
+
+	r.HandleFunc("/auth/login", authenticator.LoginHandler).Methods("POST")
+	r.HandleFunc("/auth/refresh", authenticator.RefreshHandler).Methods("POST")
+
+	// GET routes require at least the reader role; mutating routes require
+	// at least editor. See auth.Require for the role hierarchy.
+	r.Handle("/movies", authenticator.Require("reader", handler.getMovies)).Methods("GET")
+	r.Handle("/movies/{id}", authenticator.Require("reader", handler.getMovie)).Methods("GET")
+	r.Handle("/movies", authenticator.Require("editor", handler.createMovie)).Methods("POST")
+	r.Handle("/movies/{id}", authenticator.Require("editor", handler.updateMovie)).Methods("PUT")
+	r.Handle("/movies/{id}", authenticator.Require("editor", handler.patchMovie)).Methods("PATCH")
+	r.Handle("/movies/{id}", authenticator.Require("editor", handler.deleteMovie)).Methods("DELETE")
+
+	r.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openapi.Document("go-crud-api", "1.0.0", apiRoutes()))
+	}).Methods("GET")
+	r.HandleFunc("/docs", openapi.ServeUI).Methods("GET")
+
+	// starting the server and telling it to listen on port 8000 while using r
+	// (the router we defined earlier) to handle any requests. if a non-nil
+	// error is returned by ListenAndServe, we will log it and exit the program.
+	fmt.Printf("Starting server at port 8000 (storage=%s)\n", *storageKind)
+	log.Fatal(http.ListenAndServe(":8000", r))
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/shui08/go-crud-api/storage"
+)
+
+// newTestRouter wires just the /movies routes (no auth) against a fresh
+// in-memory Storage, so handler tests can hit it directly with httptest
+// instead of going through a real server or a JWT.
+func newTestRouter() (*mux.Router, *MovieHandler) {
+	h := &MovieHandler{Store: storage.NewMemory()}
+	r := mux.NewRouter()
+	r.HandleFunc("/movies", h.getMovies).Methods("GET")
+	r.HandleFunc("/movies/{id}", h.getMovie).Methods("GET")
+	r.HandleFunc("/movies", h.createMovie).Methods("POST")
+	r.HandleFunc("/movies/{id}", h.updateMovie).Methods("PUT")
+	r.HandleFunc("/movies/{id}", h.patchMovie).Methods("PATCH")
+	r.HandleFunc("/movies/{id}", h.deleteMovie).Methods("DELETE")
+	return r, h
+}
+
+func doRequest(r *mux.Router, method, path, contentType string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+var validMovieJSON = []byte(`{
+	"isbn": "9780306406157",
+	"title": "The Go Programming Language",
+	"director": {"firstname": "Alan", "lastname": "Donovan"}
+}`)
+
+func TestGetMovieNotFound(t *testing.T) {
+	r, _ := newTestRouter()
+	rec := doRequest(r, http.MethodGet, "/movies/missing", "", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestUpdateMovieNotFound(t *testing.T) {
+	r, _ := newTestRouter()
+	rec := doRequest(r, http.MethodPut, "/movies/missing", "application/json", validMovieJSON)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestPatchMovieNotFound(t *testing.T) {
+	r, _ := newTestRouter()
+	rec := doRequest(r, http.MethodPatch, "/movies/missing", "application/merge-patch+json", []byte(`{"title": "New Title"}`))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestDeleteMovieNotFound(t *testing.T) {
+	r, _ := newTestRouter()
+	rec := doRequest(r, http.MethodDelete, "/movies/missing", "", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestCreateMovieMalformedJSON(t *testing.T) {
+	r, _ := newTestRouter()
+	rec := doRequest(r, http.MethodPost, "/movies", "application/json", []byte(`{not valid json`))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestUpdateMovieMalformedJSON(t *testing.T) {
+	r, _ := newTestRouter()
+	rec := doRequest(r, http.MethodPut, "/movies/1", "application/json", []byte(`{not valid json`))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestCreateMovieValidationFailure(t *testing.T) {
+	r, _ := newTestRouter()
+	rec := doRequest(r, http.MethodPost, "/movies", "application/json", []byte(`{"title": ""}`))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestPatchMovieValidationFailure(t *testing.T) {
+	r, h := newTestRouter()
+	if _, err := h.Store.Create(storage.Movie{
+		ID:       "1",
+		Isbn:     "9780306406157",
+		Title:    "Original Title",
+		Director: &storage.Director{Firstname: "Alan", Lastname: "Donovan"},
+	}); err != nil {
+		t.Fatalf("seed Create: %v", err)
+	}
+
+	rec := doRequest(r, http.MethodPatch, "/movies/1", "application/merge-patch+json", []byte(`{"isbn": "not-an-isbn"}`))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestCreateMovieSuccess(t *testing.T) {
+	r, _ := newTestRouter()
+	rec := doRequest(r, http.MethodPost, "/movies", "application/json", validMovieJSON)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+
+	var created storage.Movie
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("created movie has no ID")
+	}
+
+	wantLocation := "/movies/" + created.ID
+	if got := rec.Header().Get("Location"); got != wantLocation {
+		t.Errorf("Location = %q, want %q", got, wantLocation)
+	}
+}
+
+func TestDeleteMovieSuccess(t *testing.T) {
+	r, h := newTestRouter()
+	if _, err := h.Store.Create(storage.Movie{
+		ID:       "1",
+		Isbn:     "9780306406157",
+		Title:    "Original Title",
+		Director: &storage.Director{Firstname: "Alan", Lastname: "Donovan"},
+	}); err != nil {
+		t.Fatalf("seed Create: %v", err)
+	}
+
+	rec := doRequest(r, http.MethodDelete, "/movies/1", "", nil)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	if _, err := h.Store.Get("1"); err != storage.ErrNotFound {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+}
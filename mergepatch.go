@@ -0,0 +1,57 @@
+package main
+
+import "encoding/json"
+
+// mergePatch applies an RFC 7396 JSON Merge Patch to doc. Both doc and
+// patch are JSON objects decoded as map[string]interface{}; nested objects
+// are merged recursively rather than replaced wholesale, so a patch that
+// only sets one subfield of a nested object (e.g. director.lastname)
+// leaves its siblings untouched. A patch value of nil removes the key.
+// doc is left untouched; the merged result is returned as a new map.
+func mergePatch(doc, patch map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(doc))
+	for k, v := range doc {
+		result[k] = v
+	}
+
+	for k, v := range patch {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+
+		patchObj, patchIsObj := v.(map[string]interface{})
+		if !patchIsObj {
+			result[k] = v
+			continue
+		}
+
+		existingObj, _ := result[k].(map[string]interface{})
+		result[k] = mergePatch(existingObj, patchObj)
+	}
+
+	return result
+}
+
+// toDoc and fromDoc round-trip a Movie through its JSON representation so
+// mergePatch can operate on it generically as a map[string]interface{}.
+
+func toDoc(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func fromDoc(doc map[string]interface{}, v interface{}) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
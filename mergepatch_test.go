@@ -0,0 +1,98 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   map[string]interface{}
+		patch map[string]interface{}
+		want  map[string]interface{}
+	}{
+		{
+			name:  "top-level field replaced",
+			doc:   map[string]interface{}{"title": "Old Title", "isbn": "123"},
+			patch: map[string]interface{}{"title": "New Title"},
+			want:  map[string]interface{}{"title": "New Title", "isbn": "123"},
+		},
+		{
+			name: "nested object merged, not replaced",
+			doc: map[string]interface{}{
+				"director": map[string]interface{}{"firstname": "Ada", "lastname": "Lovelace"},
+			},
+			patch: map[string]interface{}{
+				"director": map[string]interface{}{"lastname": "King"},
+			},
+			want: map[string]interface{}{
+				"director": map[string]interface{}{"firstname": "Ada", "lastname": "King"},
+			},
+		},
+		{
+			name:  "nil patch value removes the key",
+			doc:   map[string]interface{}{"title": "Old Title", "isbn": "123"},
+			patch: map[string]interface{}{"isbn": nil},
+			want:  map[string]interface{}{"title": "Old Title"},
+		},
+		{
+			name: "nested key added where none existed",
+			doc:  map[string]interface{}{"title": "Old Title"},
+			patch: map[string]interface{}{
+				"director": map[string]interface{}{"firstname": "Ada"},
+			},
+			want: map[string]interface{}{
+				"title":    "Old Title",
+				"director": map[string]interface{}{"firstname": "Ada"},
+			},
+		},
+		{
+			name:  "empty patch leaves doc unchanged",
+			doc:   map[string]interface{}{"title": "Old Title"},
+			patch: map[string]interface{}{},
+			want:  map[string]interface{}{"title": "Old Title"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergePatch(tt.doc, tt.patch)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergePatch(%v, %v) = %v, want %v", tt.doc, tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatchLeavesDocUntouched(t *testing.T) {
+	doc := map[string]interface{}{"title": "Old Title"}
+	patch := map[string]interface{}{"title": "New Title"}
+
+	mergePatch(doc, patch)
+
+	if doc["title"] != "Old Title" {
+		t.Errorf("mergePatch mutated its doc argument: got %v", doc["title"])
+	}
+}
+
+func TestToDocFromDocRoundTrip(t *testing.T) {
+	type simple struct {
+		Title string `json:"title"`
+		Count int    `json:"count"`
+	}
+
+	in := simple{Title: "Hello", Count: 3}
+	doc, err := toDoc(in)
+	if err != nil {
+		t.Fatalf("toDoc: %v", err)
+	}
+
+	var out simple
+	if err := fromDoc(doc, &out); err != nil {
+		t.Fatalf("fromDoc: %v", err)
+	}
+	if out != in {
+		t.Errorf("round-trip = %+v, want %+v", out, in)
+	}
+}
@@ -0,0 +1,133 @@
+// Package openapi builds an OpenAPI 3.0 document describing the API's
+// registered routes and serves it alongside Swagger UI.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Parameter describes a single query or path parameter of a Route.
+type Parameter struct {
+	Name     string
+	In       string // "query" or "path"
+	Required bool
+	Type     string // "string", "integer", ...
+}
+
+// Route describes one operation to document. Path uses OpenAPI's
+// "{param}" placeholder syntax, matching gorilla/mux's own route syntax.
+type Route struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Security    bool // true if the route requires a bearer token
+	Parameters  []Parameter
+	RequestBody interface{}         // nil, or a value whose type describes the body
+	Responses   map[int]interface{} // status -> nil (no body) or a value whose type describes it
+}
+
+// Document builds an OpenAPI 3.0 document for the given routes.
+func Document(title, version string, routes []Route) map[string]interface{} {
+	paths := map[string]interface{}{}
+	for _, route := range routes {
+		item, _ := paths[route.Path].(map[string]interface{})
+		if item == nil {
+			item = map[string]interface{}{}
+			paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation(route)
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": version,
+		},
+		"paths": paths,
+	}
+
+	if usesSecurity(routes) {
+		doc["components"] = map[string]interface{}{
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		}
+	}
+
+	return doc
+}
+
+func operation(route Route) map[string]interface{} {
+	op := map[string]interface{}{
+		"operationId": route.OperationID,
+		"summary":     route.Summary,
+		"responses":   responses(route.Responses),
+	}
+
+	if len(route.Parameters) > 0 {
+		params := make([]interface{}, len(route.Parameters))
+		for i, p := range route.Parameters {
+			params[i] = map[string]interface{}{
+				"name":     p.Name,
+				"in":       p.In,
+				"required": p.Required,
+				"schema":   map[string]interface{}{"type": p.Type},
+			}
+		}
+		op["parameters"] = params
+	}
+
+	if route.RequestBody != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": schemaForType(reflect.TypeOf(route.RequestBody)),
+				},
+			},
+		}
+	}
+
+	if route.Security {
+		op["security"] = []interface{}{map[string]interface{}{"bearerAuth": []interface{}{}}}
+	}
+
+	return op
+}
+
+func responses(specs map[int]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for status, body := range specs {
+		resp := map[string]interface{}{"description": http.StatusText(status)}
+		if body != nil {
+			resp["content"] = map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": schemaForType(reflect.TypeOf(body)),
+				},
+			}
+		}
+		out[strconv.Itoa(status)] = resp
+	}
+	if len(out) == 0 {
+		out["default"] = map[string]interface{}{"description": "default response"}
+	}
+	return out
+}
+
+func usesSecurity(routes []Route) bool {
+	for _, r := range routes {
+		if r.Security {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+// schemaForType builds a JSON Schema object for t by reflecting over its
+// exported fields. Struct fields use their "json" tag for the property
+// name, "validate:\"required\"" to mark the property required, and
+// "example" for a sample value.
+func schemaForType(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaForType(t.Elem())}
+	case reflect.Struct:
+		return schemaForStruct(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func schemaForStruct(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		prop := schemaForType(field.Type)
+		if example, ok := field.Tag.Lookup("example"); ok {
+			prop["example"] = example
+		}
+		properties[name] = prop
+
+		if strings.Contains(field.Tag.Get("validate"), "required") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return field.Name, true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		return field.Name, true
+	}
+	return name, true
+}
@@ -0,0 +1,29 @@
+package openapi
+
+import "net/http"
+
+// uiPage loads Swagger UI from a CDN and points it at /openapi.json; no
+// assets need to be vendored for a single-page docs viewer.
+const uiPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>go-crud-api docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({url: '/openapi.json', dom_id: '#swagger-ui'});
+    };
+  </script>
+</body>
+</html>
+`
+
+// ServeUI handles GET /docs, rendering Swagger UI against /openapi.json.
+func ServeUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(uiPage))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/shui08/go-crud-api/auth"
+	"github.com/shui08/go-crud-api/openapi"
+	"github.com/shui08/go-crud-api/storage"
+)
+
+// apiRoutes describes every route registered in main, for openapi.Document.
+// It is kept next to the router wiring so the two stay in sync by hand;
+// there is no single source of truth to generate both from yet.
+func apiRoutes() []openapi.Route {
+	idParam := []openapi.Parameter{{Name: "id", In: "path", Required: true, Type: "string"}}
+
+	return []openapi.Route{
+		{
+			Method: "POST", Path: "/auth/login", OperationID: "login",
+			Summary:     "Exchange a username and password for a JWT",
+			RequestBody: auth.LoginRequest{},
+			Responses:   map[int]interface{}{200: auth.TokenResponse{}, 400: APIError{}, 401: APIError{}},
+		},
+		{
+			Method: "POST", Path: "/auth/refresh", OperationID: "refresh",
+			Summary:   "Exchange a still-valid JWT for a new one",
+			Security:  true,
+			Responses: map[int]interface{}{200: auth.TokenResponse{}, 401: APIError{}},
+		},
+		{
+			Method: "GET", Path: "/movies", OperationID: "listMovies",
+			Summary:  "List movies, with optional filtering, sorting, and pagination",
+			Security: true,
+			Parameters: []openapi.Parameter{
+				{Name: "title", In: "query", Type: "string"},
+				{Name: "director", In: "query", Type: "string"},
+				{Name: "sort", In: "query", Type: "string"},
+				{Name: "limit", In: "query", Type: "integer"},
+				{Name: "offset", In: "query", Type: "integer"},
+			},
+			Responses: map[int]interface{}{200: MoviesPage{}, 400: APIError{}, 401: APIError{}},
+		},
+		{
+			Method: "GET", Path: "/movies/{id}", OperationID: "getMovie",
+			Summary: "Get a single movie by id", Security: true, Parameters: idParam,
+			Responses: map[int]interface{}{200: storage.Movie{}, 401: APIError{}, 404: APIError{}},
+		},
+		{
+			Method: "POST", Path: "/movies", OperationID: "createMovie",
+			Summary: "Create a movie", Security: true, RequestBody: storage.Movie{},
+			Responses: map[int]interface{}{201: storage.Movie{}, 400: APIError{}, 401: APIError{}, 403: APIError{}, 422: APIError{}},
+		},
+		{
+			Method: "PUT", Path: "/movies/{id}", OperationID: "updateMovie",
+			Summary: "Replace a movie", Security: true, Parameters: idParam, RequestBody: storage.Movie{},
+			Responses: map[int]interface{}{200: storage.Movie{}, 400: APIError{}, 401: APIError{}, 403: APIError{}, 404: APIError{}, 422: APIError{}},
+		},
+		{
+			Method: "PATCH", Path: "/movies/{id}", OperationID: "patchMovie",
+			Summary: "Apply a JSON Merge Patch (RFC 7396) to a movie", Security: true, Parameters: idParam,
+			Responses: map[int]interface{}{200: storage.Movie{}, 400: APIError{}, 401: APIError{}, 403: APIError{}, 404: APIError{}, 415: APIError{}, 422: APIError{}},
+		},
+		{
+			Method: "DELETE", Path: "/movies/{id}", OperationID: "deleteMovie",
+			Summary: "Delete a movie", Security: true, Parameters: idParam,
+			Responses: map[int]interface{}{204: nil, 401: APIError{}, 403: APIError{}, 404: APIError{}},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/shui08/go-crud-api/storage"
+)
+
+// MoviesPage is the envelope returned by GET /movies.
+type MoviesPage struct {
+	Data   []storage.Movie `json:"data"`
+	Total  int             `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// parseListOptions turns the ?limit=&offset=&sort=&title=&director= query
+// parameters into a storage.ListOptions, rejecting anything malformed.
+func parseListOptions(q url.Values) (storage.ListOptions, error) {
+	opts := storage.ListOptions{
+		Title:    q.Get("title"),
+		Director: q.Get("director"),
+		Sort:     q.Get("sort"),
+	}
+
+	switch opts.Sort {
+	case "", "title", "-title", "isbn", "-isbn":
+	default:
+		return opts, fmt.Errorf("sort must be one of title, -title, isbn, -isbn")
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("limit must be a non-negative integer")
+		}
+		opts.Limit = &n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("offset must be a non-negative integer")
+		}
+		opts.Offset = n
+	}
+
+	return opts, nil
+}
+
+// setPaginationLinks sets a Link header with next/prev page URLs, mirroring
+// the current request's query string but for a different offset.
+func setPaginationLinks(w http.ResponseWriter, r *http.Request, opts storage.ListOptions, total int) {
+	if opts.Limit == nil || *opts.Limit <= 0 {
+		return
+	}
+	limit := *opts.Limit
+
+	var links []string
+	if opts.Offset+limit < total {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(r, opts.Offset+limit, limit)))
+	}
+	if opts.Offset > 0 {
+		prevOffset := opts.Offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(r, prevOffset, limit)))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+func pageURL(r *http.Request, offset, limit int) string {
+	q := r.URL.Query()
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(limit))
+	u := *r.URL
+	u.RawQuery = q.Encode()
+	return u.String()
+}
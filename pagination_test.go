@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestParseListOptionsLimit(t *testing.T) {
+	t.Run("no limit given leaves Limit nil", func(t *testing.T) {
+		opts, err := parseListOptions(url.Values{})
+		if err != nil {
+			t.Fatalf("parseListOptions: %v", err)
+		}
+		if opts.Limit != nil {
+			t.Errorf("Limit = %v, want nil", *opts.Limit)
+		}
+	})
+
+	t.Run("explicit limit=0 is distinguishable from unset", func(t *testing.T) {
+		opts, err := parseListOptions(url.Values{"limit": {"0"}})
+		if err != nil {
+			t.Fatalf("parseListOptions: %v", err)
+		}
+		if opts.Limit == nil {
+			t.Fatal("Limit = nil, want a pointer to 0")
+		}
+		if *opts.Limit != 0 {
+			t.Errorf("Limit = %d, want 0", *opts.Limit)
+		}
+	})
+
+	t.Run("positive limit is parsed", func(t *testing.T) {
+		opts, err := parseListOptions(url.Values{"limit": {"5"}})
+		if err != nil {
+			t.Fatalf("parseListOptions: %v", err)
+		}
+		if opts.Limit == nil || *opts.Limit != 5 {
+			t.Errorf("Limit = %v, want 5", opts.Limit)
+		}
+	})
+
+	t.Run("negative limit is rejected", func(t *testing.T) {
+		if _, err := parseListOptions(url.Values{"limit": {"-1"}}); err == nil {
+			t.Error("expected an error for a negative limit")
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// File is a Storage that persists movies as a single JSON array on disk. It
+// reads and rewrites the whole file on every mutation, which is simple and
+// fine for the small collections this API targets.
+type File struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFile returns a File-backed store rooted at path, creating an empty
+// file there if one does not already exist.
+func NewFile(path string) (*File, error) {
+	f := &File{path: path}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := f.write(nil); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (f *File) read() ([]Movie, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var movies []Movie
+	if err := json.Unmarshal(data, &movies); err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
+
+func (f *File) write(movies []Movie) error {
+	data, err := json.MarshalIndent(movies, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, data, 0o644)
+}
+
+func (f *File) List(opts ListOptions) ([]Movie, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	movies, err := f.read()
+	if err != nil {
+		return nil, 0, err
+	}
+	return applyListOptions(movies, opts)
+}
+
+func (f *File) Get(id string) (Movie, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	movies, err := f.read()
+	if err != nil {
+		return Movie{}, err
+	}
+	for _, movie := range movies {
+		if movie.ID == id {
+			return movie, nil
+		}
+	}
+	return Movie{}, ErrNotFound
+}
+
+func (f *File) Create(movie Movie) (Movie, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	movies, err := f.read()
+	if err != nil {
+		return Movie{}, err
+	}
+	movies = append(movies, movie)
+	if err := f.write(movies); err != nil {
+		return Movie{}, err
+	}
+	return movie, nil
+}
+
+func (f *File) Update(id string, movie Movie) (Movie, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	movies, err := f.read()
+	if err != nil {
+		return Movie{}, err
+	}
+	for i, existing := range movies {
+		if existing.ID == id {
+			movie.ID = id
+			movies[i] = movie
+			if err := f.write(movies); err != nil {
+				return Movie{}, err
+			}
+			return movie, nil
+		}
+	}
+	return Movie{}, ErrNotFound
+}
+
+func (f *File) Delete(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	movies, err := f.read()
+	if err != nil {
+		return err
+	}
+	for i, existing := range movies {
+		if existing.ID == id {
+			movies = append(movies[:i], movies[i+1:]...)
+			return f.write(movies)
+		}
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"slices"
+	"strings"
+)
+
+// applyListOptions filters, sorts, and paginates an in-memory slice of
+// movies. It is shared by the Memory and File backends, which both hold
+// their movies as a plain slice; SQL pushes the same operations into the
+// query itself instead.
+func applyListOptions(movies []Movie, opts ListOptions) ([]Movie, int, error) {
+	filtered := make([]Movie, 0, len(movies))
+	title := strings.ToLower(opts.Title)
+	director := strings.ToLower(opts.Director)
+
+	for _, m := range movies {
+		if title != "" && !strings.Contains(strings.ToLower(m.Title), title) {
+			continue
+		}
+		if director != "" && !strings.Contains(directorName(m), director) {
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+
+	sortMovies(filtered, opts.Sort)
+
+	total := len(filtered)
+	start := min(opts.Offset, total)
+	end := total
+	if opts.Limit != nil {
+		limit := max(*opts.Limit, 0)
+		if start+limit < end {
+			end = start + limit
+		}
+	}
+	return filtered[start:end], total, nil
+}
+
+func directorName(m Movie) string {
+	if m.Director == nil {
+		return ""
+	}
+	return strings.ToLower(m.Director.Firstname + " " + m.Director.Lastname)
+}
+
+func sortMovies(movies []Movie, sort string) {
+	switch sort {
+	case "title":
+		slices.SortFunc(movies, func(a, b Movie) int { return strings.Compare(a.Title, b.Title) })
+	case "-title":
+		slices.SortFunc(movies, func(a, b Movie) int { return strings.Compare(b.Title, a.Title) })
+	case "isbn":
+		slices.SortFunc(movies, func(a, b Movie) int { return strings.Compare(a.Isbn, b.Isbn) })
+	case "-isbn":
+		slices.SortFunc(movies, func(a, b Movie) int { return strings.Compare(b.Isbn, a.Isbn) })
+	}
+}
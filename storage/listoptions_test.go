@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func intPtr(n int) *int { return &n }
+
+func sampleMovies() []Movie {
+	return []Movie{
+		{ID: "1", Isbn: "1111111111", Title: "Bravo", Director: &Director{Firstname: "Ada", Lastname: "Lovelace"}},
+		{ID: "2", Isbn: "2222222222", Title: "Alpha", Director: &Director{Firstname: "Grace", Lastname: "Hopper"}},
+		{ID: "3", Isbn: "3333333333", Title: "Charlie", Director: &Director{Firstname: "Alan", Lastname: "Turing"}},
+	}
+}
+
+func titles(movies []Movie) []string {
+	out := make([]string, len(movies))
+	for i, m := range movies {
+		out[i] = m.Title
+	}
+	return out
+}
+
+func TestApplyListOptionsFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []string
+	}{
+		{"no filter", ListOptions{}, []string{"Bravo", "Alpha", "Charlie"}},
+		{"filter by title substring, case-insensitive", ListOptions{Title: "ALP"}, []string{"Alpha"}},
+		{"filter by director first name", ListOptions{Director: "ada"}, []string{"Bravo"}},
+		{"filter by director last name", ListOptions{Director: "turing"}, []string{"Charlie"}},
+		{"filter matches nothing", ListOptions{Title: "nonexistent"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := applyListOptions(sampleMovies(), tt.opts)
+			if err != nil {
+				t.Fatalf("applyListOptions: %v", err)
+			}
+			if total != len(tt.want) {
+				t.Errorf("total = %d, want %d", total, len(tt.want))
+			}
+			if !reflect.DeepEqual(titles(got), tt.want) {
+				t.Errorf("titles = %v, want %v", titles(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyListOptionsSort(t *testing.T) {
+	tests := []struct {
+		name string
+		sort string
+		want []string
+	}{
+		{"sort by title ascending", "title", []string{"Alpha", "Bravo", "Charlie"}},
+		{"sort by title descending", "-title", []string{"Charlie", "Bravo", "Alpha"}},
+		{"no sort leaves input order", "", []string{"Bravo", "Alpha", "Charlie"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := applyListOptions(sampleMovies(), ListOptions{Sort: tt.sort})
+			if err != nil {
+				t.Fatalf("applyListOptions: %v", err)
+			}
+			if !reflect.DeepEqual(titles(got), tt.want) {
+				t.Errorf("titles = %v, want %v", titles(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyListOptionsPagination(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  ListOptions
+		want  []string
+		total int
+	}{
+		{"limit caps the page", ListOptions{Limit: intPtr(2)}, []string{"Bravo", "Alpha"}, 3},
+		{"offset skips leading rows", ListOptions{Offset: 1}, []string{"Alpha", "Charlie"}, 3},
+		{"offset beyond length returns empty", ListOptions{Offset: 10}, []string{}, 3},
+		{"limit and offset combined", ListOptions{Offset: 1, Limit: intPtr(1)}, []string{"Alpha"}, 3},
+		{"explicit limit=0 returns an empty page", ListOptions{Limit: intPtr(0)}, []string{}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := applyListOptions(sampleMovies(), tt.opts)
+			if err != nil {
+				t.Fatalf("applyListOptions: %v", err)
+			}
+			if total != tt.total {
+				t.Errorf("total = %d, want %d", total, tt.total)
+			}
+			if !reflect.DeepEqual(titles(got), tt.want) {
+				t.Errorf("titles = %v, want %v", titles(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestDirectorName(t *testing.T) {
+	if got := directorName(Movie{Director: &Director{Firstname: "Ada", Lastname: "Lovelace"}}); got != "ada lovelace" {
+		t.Errorf("directorName = %q, want %q", got, "ada lovelace")
+	}
+	if got := directorName(Movie{}); got != "" {
+		t.Errorf("directorName with nil Director = %q, want empty string", got)
+	}
+}
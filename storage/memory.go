@@ -0,0 +1,74 @@
+package storage
+
+import "sync"
+
+// Memory is a Storage backed by a slice held in process memory. It has no
+// persistence across restarts; it exists for local development and tests.
+type Memory struct {
+	mu     sync.Mutex
+	movies []Movie
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Seed appends movies to the store directly, bypassing Create. It is meant
+// for populating fixture data at startup.
+func (m *Memory) Seed(movies ...Movie) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.movies = append(m.movies, movies...)
+}
+
+func (m *Memory) List(opts ListOptions) ([]Movie, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := make([]Movie, len(m.movies))
+	copy(snapshot, m.movies)
+	return applyListOptions(snapshot, opts)
+}
+
+func (m *Memory) Get(id string) (Movie, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, movie := range m.movies {
+		if movie.ID == id {
+			return movie, nil
+		}
+	}
+	return Movie{}, ErrNotFound
+}
+
+func (m *Memory) Create(movie Movie) (Movie, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.movies = append(m.movies, movie)
+	return movie, nil
+}
+
+func (m *Memory) Update(id string, movie Movie) (Movie, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, existing := range m.movies {
+		if existing.ID == id {
+			movie.ID = id
+			m.movies[i] = movie
+			return movie, nil
+		}
+	}
+	return Movie{}, ErrNotFound
+}
+
+func (m *Memory) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, existing := range m.movies {
+		if existing.ID == id {
+			m.movies = append(m.movies[:i], m.movies[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoTimeout bounds every call made against the Mongo backend so a
+// wedged connection can't hang a request forever.
+const mongoTimeout = 5 * time.Second
+
+// movieDoc is what actually gets stored in MongoDB: Movie's own bson-tagged
+// fields, inlined, alongside the driver-managed ObjectID primary key. The
+// API's opaque string Movie.ID (a UUID minted by the handler, see
+// createMovie) is kept as a regular indexed field so lookups by id behave
+// the same as on every other backend.
+type movieDoc struct {
+	ObjectID primitive.ObjectID `bson:"_id,omitempty"`
+	Movie    `bson:",inline"`
+}
+
+// Mongo is a Storage backed by a MongoDB collection.
+type Mongo struct {
+	collection *mongo.Collection
+}
+
+// OpenMongo connects to uri and returns a Storage backed by
+// database.collection, creating a unique index on the "id" field used for
+// lookups.
+func OpenMongo(ctx context.Context, uri, database, collection string) (*Mongo, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	coll := client.Database(database).Collection(collection)
+	_, err = coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mongo{collection: coll}, nil
+}
+
+func (m *Mongo) List(opts ListOptions) ([]Movie, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoTimeout)
+	defer cancel()
+
+	filter := bson.M{}
+	if opts.Title != "" {
+		filter["title"] = bson.M{"$regex": regexp.QuoteMeta(opts.Title), "$options": "i"}
+	}
+	if opts.Director != "" {
+		// Matched against "firstname lastname" concatenated, same as the
+		// SQL/Memory/File backends, so a query like "ada lovelace" behaves
+		// identically everywhere; $ifNull covers movies with no director.
+		filter["$expr"] = bson.M{
+			"$regexMatch": bson.M{
+				"input": bson.M{"$concat": bson.A{
+					bson.M{"$ifNull": bson.A{"$director.firstname", ""}},
+					" ",
+					bson.M{"$ifNull": bson.A{"$director.lastname", ""}},
+				}},
+				"regex":   regexp.QuoteMeta(opts.Director),
+				"options": "i",
+			},
+		}
+	}
+
+	total, err := m.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if opts.Limit != nil && *opts.Limit == 0 {
+		// The driver's SetLimit(0) means "no limit", not "zero results", so
+		// an explicit limit=0 has to short-circuit before it reaches Find.
+		return []Movie{}, int(total), nil
+	}
+
+	findOpts := options.Find()
+	if field, dir := mongoSort(opts.Sort); field != "" {
+		findOpts.SetSort(bson.D{{Key: field, Value: dir}})
+	}
+	if opts.Limit != nil {
+		findOpts.SetLimit(int64(*opts.Limit))
+	}
+	if opts.Offset > 0 {
+		findOpts.SetSkip(int64(opts.Offset))
+	}
+
+	cur, err := m.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+
+	var movies []Movie
+	for cur.Next(ctx) {
+		var doc movieDoc
+		if err := cur.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		movies = append(movies, doc.Movie)
+	}
+	return movies, int(total), cur.Err()
+}
+
+func mongoSort(sort string) (field string, dir int) {
+	switch sort {
+	case "title":
+		return "title", 1
+	case "-title":
+		return "title", -1
+	case "isbn":
+		return "isbn", 1
+	case "-isbn":
+		return "isbn", -1
+	default:
+		return "", 0
+	}
+}
+
+func (m *Mongo) Get(id string) (Movie, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoTimeout)
+	defer cancel()
+
+	var doc movieDoc
+	err := m.collection.FindOne(ctx, bson.M{"id": id}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return Movie{}, ErrNotFound
+	}
+	if err != nil {
+		return Movie{}, err
+	}
+	return doc.Movie, nil
+}
+
+func (m *Mongo) Create(movie Movie) (Movie, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoTimeout)
+	defer cancel()
+
+	if _, err := m.collection.InsertOne(ctx, movieDoc{Movie: movie}); err != nil {
+		return Movie{}, err
+	}
+	return movie, nil
+}
+
+func (m *Mongo) Update(id string, movie Movie) (Movie, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoTimeout)
+	defer cancel()
+
+	movie.ID = id
+	res, err := m.collection.ReplaceOne(ctx, bson.M{"id": id}, movieDoc{Movie: movie})
+	if err != nil {
+		return Movie{}, err
+	}
+	if res.MatchedCount == 0 {
+		return Movie{}, ErrNotFound
+	}
+	return movie, nil
+}
+
+func (m *Mongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoTimeout)
+	defer cancel()
+
+	res, err := m.collection.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
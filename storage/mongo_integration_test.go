@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestMongoIntegration exercises OpenMongo and the Storage methods against a
+// real MongoDB instance. It is skipped under -short, and also skipped (rather
+// than failed) when MONGODB_TEST_URI isn't set, since no testcontainers-go
+// dependency has been added to this module yet and CI/dev boxes won't
+// generally have a Mongo instance listening.
+func TestMongoIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping Mongo integration test in -short mode")
+	}
+
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI not set; skipping Mongo integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := "go_crud_api_test"
+	coll := "movies"
+	store, err := OpenMongo(ctx, uri, db, coll)
+	if err != nil {
+		t.Fatalf("OpenMongo: %v", err)
+	}
+	t.Cleanup(func() {
+		store.collection.Drop(context.Background())
+	})
+
+	movie := Movie{
+		ID:    "integration-1",
+		Isbn:  "9780306406157",
+		Title: "Test Movie",
+		Director: &Director{
+			Firstname: "Ada",
+			Lastname:  "Lovelace",
+		},
+	}
+
+	if _, err := store.Create(movie); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := store.Get(movie.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != movie.Title {
+		t.Errorf("Get title = %q, want %q", got.Title, movie.Title)
+	}
+
+	movie.Title = "Updated Title"
+	if _, err := store.Update(movie.ID, movie); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	movies, total, err := store.List(ListOptions{Title: "Updated"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 || len(movies) != 1 {
+		t.Fatalf("List returned %d/%d movies, want 1/1", len(movies), total)
+	}
+
+	if err := store.Delete(movie.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(movie.ID); err != ErrNotFound {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,21 @@
+package storage
+
+// Director identifies the person credited with directing a Movie. The
+// validate and example tags are read by the openapi package to generate
+// its JSON schema; no other code inspects them.
+type Director struct {
+	Firstname string `json:"firstname" bson:"firstname" validate:"required" example:"Christopher"`
+	Lastname  string `json:"lastname" bson:"lastname" validate:"required" example:"Nolan"`
+}
+
+// Movie is the resource the API reads and writes. It carries its Director
+// inline (as opposed to a foreign key) so that callers of Storage never have
+// to make a second round trip to render a full movie. The bson tags are
+// used by the Mongo backend; validate and example are used by the openapi
+// package; other backends ignore both.
+type Movie struct {
+	ID       string    `json:"id" bson:"id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	Isbn     string    `json:"isbn" bson:"isbn" validate:"required" example:"978-3-16-148410-0"`
+	Title    string    `json:"title" bson:"title" validate:"required" example:"Inception"`
+	Director *Director `json:"director" bson:"director,omitempty" validate:"required"`
+}
@@ -0,0 +1,326 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// SQL is a Storage backed by database/sql. It is driven purely through the
+// standard library plus whichever driver the caller has registered (see the
+// blank imports of github.com/mattn/go-sqlite3 and github.com/lib/pq in
+// main), so OpenSQL only needs to know the driver's name and its SQL
+// dialect quirks (bind-variable syntax, auto-increment, RETURNING support).
+type SQL struct {
+	db     *sql.DB
+	driver string
+}
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS directors (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	firstname TEXT NOT NULL,
+	lastname TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS movies (
+	id TEXT PRIMARY KEY,
+	isbn TEXT NOT NULL,
+	title TEXT NOT NULL,
+	director_id INTEGER REFERENCES directors(id)
+);
+`
+
+const postgresSchema = `
+CREATE TABLE IF NOT EXISTS directors (
+	id SERIAL PRIMARY KEY,
+	firstname TEXT NOT NULL,
+	lastname TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS movies (
+	id TEXT PRIMARY KEY,
+	isbn TEXT NOT NULL,
+	title TEXT NOT NULL,
+	director_id INTEGER REFERENCES directors(id)
+);
+`
+
+// OpenSQL opens a database/sql connection for driver ("sqlite3" or
+// "postgres"), applies the movies/directors migrations, and returns a ready
+// to use Storage.
+func OpenSQL(driver, dsn string) (*SQL, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", driver, err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("ping %s: %w", driver, err)
+	}
+	s := &SQL{db: db, driver: driver}
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SQL) migrate() error {
+	schema := sqliteSchema
+	if s.driver == "postgres" {
+		schema = postgresSchema
+	}
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	return nil
+}
+
+// placeholder returns the n-th (1-indexed) bind variable in this driver's
+// dialect: sqlite3 uses "?" everywhere, postgres uses positional "$n".
+func (s *SQL) placeholder(n int) string {
+	if s.driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows, letting Get and
+// List share a single scan routine.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMovie(row rowScanner) (Movie, error) {
+	var m Movie
+	var firstname, lastname sql.NullString
+	if err := row.Scan(&m.ID, &m.Isbn, &m.Title, &firstname, &lastname); err != nil {
+		return Movie{}, err
+	}
+	if firstname.Valid || lastname.Valid {
+		m.Director = &Director{Firstname: firstname.String, Lastname: lastname.String}
+	}
+	return m, nil
+}
+
+const movieSelect = `
+	SELECT m.id, m.isbn, m.title, d.firstname, d.lastname
+	FROM movies m
+	LEFT JOIN directors d ON d.id = m.director_id
+`
+
+// listWhere builds the WHERE clause (and its bind arguments) for opts'
+// Title/Director filters.
+func (s *SQL) listWhere(opts ListOptions) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if opts.Title != "" {
+		args = append(args, "%"+strings.ToLower(opts.Title)+"%")
+		clauses = append(clauses, fmt.Sprintf("LOWER(m.title) LIKE %s", s.placeholder(len(args))))
+	}
+	if opts.Director != "" {
+		args = append(args, "%"+strings.ToLower(opts.Director)+"%")
+		clauses = append(clauses, fmt.Sprintf("LOWER(d.firstname || ' ' || d.lastname) LIKE %s", s.placeholder(len(args))))
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+func (s *SQL) orderBy(sort string) string {
+	switch sort {
+	case "title":
+		return " ORDER BY m.title ASC"
+	case "-title":
+		return " ORDER BY m.title DESC"
+	case "isbn":
+		return " ORDER BY m.isbn ASC"
+	case "-isbn":
+		return " ORDER BY m.isbn DESC"
+	default:
+		return " ORDER BY m.id"
+	}
+}
+
+func (s *SQL) countMovies(where string, args []interface{}) (int, error) {
+	query := "SELECT COUNT(*) FROM movies m LEFT JOIN directors d ON d.id = m.director_id" + where
+	var total int
+	err := s.db.QueryRow(query, args...).Scan(&total)
+	return total, err
+}
+
+func (s *SQL) List(opts ListOptions) ([]Movie, int, error) {
+	where, args := s.listWhere(opts)
+
+	total, err := s.countMovies(where, args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := movieSelect + where + s.orderBy(opts.Sort)
+	if opts.Limit != nil {
+		args = append(args, *opts.Limit)
+		query += fmt.Sprintf(" LIMIT %s", s.placeholder(len(args)))
+	}
+	if opts.Offset > 0 {
+		args = append(args, opts.Offset)
+		query += fmt.Sprintf(" OFFSET %s", s.placeholder(len(args)))
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var movies []Movie
+	for rows.Next() {
+		m, err := scanMovie(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		movies = append(movies, m)
+	}
+	return movies, total, rows.Err()
+}
+
+func (s *SQL) Get(id string) (Movie, error) {
+	row := s.db.QueryRow(movieSelect+fmt.Sprintf(" WHERE m.id = %s", s.placeholder(1)), id)
+	m, err := scanMovie(row)
+	if err == sql.ErrNoRows {
+		return Movie{}, ErrNotFound
+	}
+	return m, err
+}
+
+// insertDirector inserts a director row and returns its generated id.
+func (s *SQL) insertDirector(tx *sql.Tx, d Director) (int64, error) {
+	if s.driver == "postgres" {
+		var id int64
+		err := tx.QueryRow(
+			"INSERT INTO directors (firstname, lastname) VALUES ($1, $2) RETURNING id",
+			d.Firstname, d.Lastname,
+		).Scan(&id)
+		return id, err
+	}
+	res, err := tx.Exec("INSERT INTO directors (firstname, lastname) VALUES (?, ?)", d.Firstname, d.Lastname)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (s *SQL) Create(movie Movie) (Movie, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Movie{}, err
+	}
+	defer tx.Rollback()
+
+	var directorID sql.NullInt64
+	if movie.Director != nil {
+		id, err := s.insertDirector(tx, *movie.Director)
+		if err != nil {
+			return Movie{}, err
+		}
+		directorID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(
+		"INSERT INTO movies (id, isbn, title, director_id) VALUES (%s, %s, %s, %s)",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4),
+	), movie.ID, movie.Isbn, movie.Title, directorID)
+	if err != nil {
+		return Movie{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Movie{}, err
+	}
+	return movie, nil
+}
+
+func (s *SQL) Update(id string, movie Movie) (Movie, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Movie{}, err
+	}
+	defer tx.Rollback()
+
+	var existingDirectorID sql.NullInt64
+	err = tx.QueryRow(fmt.Sprintf("SELECT director_id FROM movies WHERE id = %s", s.placeholder(1)), id).Scan(&existingDirectorID)
+	if err == sql.ErrNoRows {
+		return Movie{}, ErrNotFound
+	}
+	if err != nil {
+		return Movie{}, err
+	}
+
+	directorID, err := s.upsertDirector(tx, existingDirectorID, movie.Director)
+	if err != nil {
+		return Movie{}, err
+	}
+
+	res, err := tx.Exec(fmt.Sprintf(
+		"UPDATE movies SET isbn = %s, title = %s, director_id = %s WHERE id = %s",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4),
+	), movie.Isbn, movie.Title, directorID, id)
+	if err != nil {
+		return Movie{}, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return Movie{}, err
+	} else if n == 0 {
+		return Movie{}, ErrNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return Movie{}, err
+	}
+	movie.ID = id
+	return movie, nil
+}
+
+// upsertDirector reconciles a movie's director row against its new value d:
+// if existing names a director row and d is non-nil, that row is updated in
+// place so PUT/PATCH don't leak a fresh directors row on every call; if d is
+// nil, the now-unreferenced existing row is deleted; otherwise a new row is
+// inserted as usual. It returns the director_id to store on movies.
+func (s *SQL) upsertDirector(tx *sql.Tx, existing sql.NullInt64, d *Director) (sql.NullInt64, error) {
+	if d == nil {
+		if existing.Valid {
+			if _, err := tx.Exec(fmt.Sprintf("DELETE FROM directors WHERE id = %s", s.placeholder(1)), existing.Int64); err != nil {
+				return sql.NullInt64{}, err
+			}
+		}
+		return sql.NullInt64{}, nil
+	}
+
+	if existing.Valid {
+		_, err := tx.Exec(fmt.Sprintf(
+			"UPDATE directors SET firstname = %s, lastname = %s WHERE id = %s",
+			s.placeholder(1), s.placeholder(2), s.placeholder(3),
+		), d.Firstname, d.Lastname, existing.Int64)
+		if err != nil {
+			return sql.NullInt64{}, err
+		}
+		return existing, nil
+	}
+
+	id, err := s.insertDirector(tx, *d)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	return sql.NullInt64{Int64: id, Valid: true}, nil
+}
+
+func (s *SQL) Delete(id string) error {
+	res, err := s.db.Exec(fmt.Sprintf("DELETE FROM movies WHERE id = %s", s.placeholder(1)), id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestSQL(t *testing.T) *SQL {
+	t.Helper()
+	s, err := OpenSQL("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("OpenSQL: %v", err)
+	}
+	t.Cleanup(func() { s.db.Close() })
+	return s
+}
+
+func countDirectors(t *testing.T, s *SQL) int {
+	t.Helper()
+	var n int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM directors").Scan(&n); err != nil {
+		t.Fatalf("count directors: %v", err)
+	}
+	return n
+}
+
+func TestSQLUpdateReusesDirectorRow(t *testing.T) {
+	s := newTestSQL(t)
+
+	movie := Movie{ID: "1", Isbn: "9780306406157", Title: "Original Title", Director: &Director{Firstname: "Ada", Lastname: "Lovelace"}}
+	if _, err := s.Create(movie); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if got := countDirectors(t, s); got != 1 {
+		t.Fatalf("directors after Create = %d, want 1", got)
+	}
+
+	movie.Title = "Updated Title"
+	movie.Director = &Director{Firstname: "Ada", Lastname: "King"}
+	updated, err := s.Update("1", movie)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Title != "Updated Title" {
+		t.Errorf("Title = %q, want %q", updated.Title, "Updated Title")
+	}
+
+	if got := countDirectors(t, s); got != 1 {
+		t.Errorf("directors after Update = %d, want 1 (update should reuse the row, not leak a new one)", got)
+	}
+
+	got, err := s.Get("1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Director == nil || got.Director.Lastname != "King" {
+		t.Errorf("Get director = %+v, want lastname King", got.Director)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.Update("1", movie); err != nil {
+			t.Fatalf("Update #%d: %v", i, err)
+		}
+	}
+	if got := countDirectors(t, s); got != 1 {
+		t.Errorf("directors after repeated Update = %d, want 1", got)
+	}
+}
+
+func TestSQLUpdateRemovesDirectorWhenCleared(t *testing.T) {
+	s := newTestSQL(t)
+
+	movie := Movie{ID: "1", Isbn: "9780306406157", Title: "Original Title", Director: &Director{Firstname: "Ada", Lastname: "Lovelace"}}
+	if _, err := s.Create(movie); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	movie.Director = nil
+	if _, err := s.Update("1", movie); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if got := countDirectors(t, s); got != 0 {
+		t.Errorf("directors after clearing = %d, want 0", got)
+	}
+
+	got, err := s.Get("1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Director != nil {
+		t.Errorf("Get director = %+v, want nil", got.Director)
+	}
+}
+
+func TestSQLUpdateNotFound(t *testing.T) {
+	s := newTestSQL(t)
+	_, err := s.Update("missing", Movie{Isbn: "9780306406157", Title: "Title"})
+	if err != ErrNotFound {
+		t.Errorf("Update on missing id = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLListExplicitZeroLimit(t *testing.T) {
+	s := newTestSQL(t)
+	for i := 0; i < 3; i++ {
+		movie := Movie{ID: string(rune('1' + i)), Isbn: "9780306406157", Title: "Title"}
+		if _, err := s.Create(movie); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	limit := 0
+	movies, total, err := s.List(ListOptions{Limit: &limit})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("total = %d, want 3", total)
+	}
+	if len(movies) != 0 {
+		t.Errorf("len(movies) = %d, want 0 for an explicit limit=0", len(movies))
+	}
+}
@@ -0,0 +1,41 @@
+package storage
+
+import "errors"
+
+// ErrNotFound is returned by a Storage implementation when the requested
+// movie ID does not exist.
+var ErrNotFound = errors.New("storage: movie not found")
+
+// ListOptions controls filtering, sorting, and pagination for Storage.List.
+// The zero value lists every movie, unsorted, with no limit.
+type ListOptions struct {
+	// Title and Director, when non-empty, keep only movies whose title (or
+	// director's full name) contains the value, case-insensitively.
+	Title    string
+	Director string
+
+	// Sort is one of "", "title", "-title", "isbn", or "-isbn". "" leaves
+	// the backend's natural order untouched.
+	Sort string
+
+	// Limit caps the number of movies returned. nil means no limit was
+	// requested; callers must distinguish that from an explicit Limit of 0,
+	// which asks for an empty page rather than "everything".
+	Limit  *int
+	Offset int
+}
+
+// Storage is implemented by every movie persistence backend the API can be
+// wired against (in-memory, JSON file, SQL, ...). Handlers depend on this
+// interface rather than a concrete backend so fakes can be injected in
+// tests.
+type Storage interface {
+	// List returns the movies matching opts along with the total count of
+	// matching movies before Limit/Offset were applied, so callers can
+	// build a pagination envelope.
+	List(opts ListOptions) (movies []Movie, total int, err error)
+	Get(id string) (Movie, error)
+	Create(movie Movie) (Movie, error)
+	Update(id string, movie Movie) (Movie, error)
+	Delete(id string) error
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/shui08/go-crud-api/storage"
+)
+
+// validateMovie checks the fields required to accept a movie from a
+// request body, returning one message per problem found (nil if valid).
+func validateMovie(m storage.Movie) []string {
+	var problems []string
+
+	if strings.TrimSpace(m.Title) == "" {
+		problems = append(problems, "title is required")
+	}
+
+	if m.Director == nil ||
+		strings.TrimSpace(m.Director.Firstname) == "" ||
+		strings.TrimSpace(m.Director.Lastname) == "" {
+		problems = append(problems, "director firstname and lastname are required")
+	}
+
+	if !validISBN(m.Isbn) {
+		problems = append(problems, "isbn must be a valid ISBN-10 or ISBN-13")
+	}
+
+	return problems
+}
+
+// validISBN reports whether s is a checksum-valid ISBN-10 or ISBN-13,
+// ignoring any hyphens or spaces used to group its digits.
+func validISBN(s string) bool {
+	digits := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, s)
+
+	switch len(digits) {
+	case 10:
+		return isValidISBN10(digits)
+	case 13:
+		return isValidISBN13(digits)
+	default:
+		return false
+	}
+}
+
+func isValidISBN10(s string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		c := s[i]
+		var d int
+		switch {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case (c == 'X' || c == 'x') && i == 9:
+			d = 10
+		default:
+			return false
+		}
+		sum += d * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+func isValidISBN13(s string) bool {
+	sum := 0
+	for i := 0; i < 13; i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if i%2 == 0 {
+			sum += d
+		} else {
+			sum += d * 3
+		}
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/shui08/go-crud-api/storage"
+)
+
+func TestValidISBN(t *testing.T) {
+	tests := []struct {
+		name string
+		isbn string
+		want bool
+	}{
+		{"valid isbn-10", "0306406152", true},
+		{"valid isbn-10 with hyphens", "0-306-40615-2", true},
+		{"valid isbn-10 with X check digit", "097522980X", true},
+		{"invalid isbn-10 checksum", "0306406153", false},
+		{"valid isbn-13", "9780306406157", true},
+		{"valid isbn-13 with hyphens", "978-0-306-40615-7", true},
+		{"invalid isbn-13 checksum", "9780306406158", false},
+		{"wrong length", "12345", false},
+		{"non-digit characters", "abcdefghij", false},
+		{"empty string", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validISBN(tt.isbn); got != tt.want {
+				t.Errorf("validISBN(%q) = %v, want %v", tt.isbn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMovie(t *testing.T) {
+	tests := []struct {
+		name    string
+		movie   storage.Movie
+		wantLen int
+	}{
+		{
+			name: "valid movie",
+			movie: storage.Movie{
+				Isbn:     "9780306406157",
+				Title:    "The Go Programming Language",
+				Director: &storage.Director{Firstname: "Alan", Lastname: "Donovan"},
+			},
+			wantLen: 0,
+		},
+		{
+			name:    "missing title",
+			movie:   storage.Movie{Isbn: "9780306406157", Director: &storage.Director{Firstname: "Alan", Lastname: "Donovan"}},
+			wantLen: 1,
+		},
+		{
+			name:    "missing director",
+			movie:   storage.Movie{Isbn: "9780306406157", Title: "Some Title"},
+			wantLen: 1,
+		},
+		{
+			name:    "invalid isbn",
+			movie:   storage.Movie{Isbn: "123", Title: "Some Title", Director: &storage.Director{Firstname: "Alan", Lastname: "Donovan"}},
+			wantLen: 1,
+		},
+		{
+			name:    "all fields missing or invalid",
+			movie:   storage.Movie{},
+			wantLen: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := validateMovie(tt.movie)
+			if len(problems) != tt.wantLen {
+				t.Errorf("validateMovie(%+v) = %v, want %d problem(s)", tt.movie, problems, tt.wantLen)
+			}
+		})
+	}
+}